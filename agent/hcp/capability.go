@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hcp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/agent/hcp/config"
+	"github.com/hashicorp/consul/agent/hcp/scada"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Capability is a pluggable Manager subsystem, analogous to the HCP link
+// capability model. Concrete capabilities (SCADA, telemetry, management
+// token upsert, ...) are registered via ManagerConfig.Capabilities and are
+// started and stopped by Manager.Run (Leave stops them too) and reconfigured
+// by Manager.UpdateConfig, without further special-casing there.
+type Capability interface {
+	// Name identifies the capability, both for logging and as the key in the
+	// capabilities-status map reported to HCP.
+	Name() string
+	Start(ctx context.Context, cfg ManagerConfig) error
+	Stop() error
+	OnConfigUpdate(cfg ManagerConfig)
+}
+
+// defaultCapabilities builds the built-in capability set from the legacy
+// ManagerConfig fields, preserving Manager's existing behavior for callers
+// that haven't populated ManagerConfig.Capabilities themselves. Capabilities
+// named in disabled are left out, so whoever assembles ManagerConfig can turn
+// individual ones off without replacing the whole set; no CloudConfig setting
+// fills in disabled.
+func defaultCapabilities(logger hclog.Logger, disabled map[string]bool) []Capability {
+	all := []Capability{
+		newSCADACapability(logger),
+		newTelemetryCapability(logger),
+		newManagementTokenCapability(logger),
+	}
+
+	if len(disabled) == 0 {
+		return all
+	}
+
+	capabilities := make([]Capability, 0, len(all))
+	for _, c := range all {
+		if disabled[c.Name()] {
+			continue
+		}
+		capabilities = append(capabilities, c)
+	}
+	return capabilities
+}
+
+// scadaCapability brings up the SCADA provider and keeps its HCP
+// configuration current.
+type scadaCapability struct {
+	logger hclog.Logger
+
+	mu       sync.Mutex
+	provider scada.Provider
+}
+
+func newSCADACapability(logger hclog.Logger) *scadaCapability {
+	return &scadaCapability{logger: logger}
+}
+
+func (c *scadaCapability) Name() string { return "scada" }
+
+func (c *scadaCapability) Start(ctx context.Context, cfg ManagerConfig) error {
+	provider := cfg.SCADAProvider
+
+	c.mu.Lock()
+	c.provider = provider
+	c.mu.Unlock()
+
+	if provider == nil {
+		return nil
+	}
+
+	c.logger.Debug("updating scada provider with HCP configuration")
+	if err := provider.UpdateHCPConfig(cfg.CloudConfig); err != nil {
+		c.logger.Error("failed to update scada provider with HCP configuration", "err", err)
+		return err
+	}
+
+	provider.UpdateMeta(map[string]string{
+		"consul_server_id": string(cfg.CloudConfig.NodeID),
+	})
+
+	return provider.Start()
+}
+
+func (c *scadaCapability) Stop() error {
+	c.mu.Lock()
+	provider := c.provider
+	c.mu.Unlock()
+
+	if provider == nil {
+		return nil
+	}
+	return provider.Stop()
+}
+
+func (c *scadaCapability) OnConfigUpdate(cfg ManagerConfig) {
+	c.mu.Lock()
+	provider := c.provider
+	c.mu.Unlock()
+
+	if cfg.SCADAProvider == nil {
+		return
+	}
+
+	if provider == nil {
+		// SCADA wasn't configured when Start ran; this is the first time a
+		// provider has shown up, so bring it up the same way Start would.
+		if err := c.Start(context.Background(), cfg); err != nil {
+			c.logger.Error("failed to start scada provider on config update", "err", err)
+		}
+		return
+	}
+
+	if err := provider.UpdateHCPConfig(cfg.CloudConfig); err != nil {
+		c.logger.Error("failed to update scada provider with HCP configuration", "err", err)
+	}
+}
+
+// telemetryCapability enables the HCP metrics sink via the telemetry
+// provider.
+type telemetryCapability struct {
+	logger hclog.Logger
+
+	mu       sync.Mutex
+	provider *hcpProviderImpl
+	// cloudCfg is what the HCPConfig pointer handed to the provider's Run
+	// points at, so OnConfigUpdate can refresh it in place.
+	cloudCfg config.CloudConfig
+}
+
+func newTelemetryCapability(logger hclog.Logger) *telemetryCapability {
+	return &telemetryCapability{logger: logger}
+}
+
+func (c *telemetryCapability) Name() string { return "telemetry" }
+
+func (c *telemetryCapability) Start(ctx context.Context, cfg ManagerConfig) error {
+	if cfg.TelemetryProvider == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	c.provider = cfg.TelemetryProvider
+	c.cloudCfg = cfg.CloudConfig
+	c.mu.Unlock()
+
+	cfg.TelemetryProvider.Run(ctx, &HCPProviderCfg{
+		HCPClient: cfg.Client,
+		HCPConfig: &c.cloudCfg,
+	})
+
+	return nil
+}
+
+func (c *telemetryCapability) Stop() error {
+	c.mu.Lock()
+	provider := c.provider
+	c.mu.Unlock()
+
+	if provider == nil {
+		return nil
+	}
+	provider.Stop()
+	return nil
+}
+
+func (c *telemetryCapability) OnConfigUpdate(cfg ManagerConfig) {
+	// The telemetry provider reads HCPConfig through the pointer passed to
+	// Run, so refreshing the CloudConfig it points at is enough. It keeps the
+	// HCPClient it was given at Start.
+	c.mu.Lock()
+	c.cloudCfg = cfg.CloudConfig
+	c.mu.Unlock()
+}
+
+// managementTokenCapability periodically upserts the HCP-issued management
+// token into the local ACL system, retrying on its own schedule rather than
+// piggybacking on the status heartbeat.
+type managementTokenCapability struct {
+	logger hclog.Logger
+
+	mu  sync.Mutex
+	cfg ManagerConfig
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+func newManagementTokenCapability(logger hclog.Logger) *managementTokenCapability {
+	return &managementTokenCapability{logger: logger}
+}
+
+func (c *managementTokenCapability) Name() string { return "management-token" }
+
+func (c *managementTokenCapability) Start(ctx context.Context, cfg ManagerConfig) error {
+	c.mu.Lock()
+	c.cfg = cfg
+	c.stopCh = make(chan struct{})
+	c.mu.Unlock()
+
+	go c.run(ctx)
+	return nil
+}
+
+func (c *managementTokenCapability) run(ctx context.Context) {
+	c.upsert()
+
+	c.mu.Lock()
+	interval := c.cfg.managementTokenUpsertInterval()
+	c.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.upsert()
+		}
+	}
+}
+
+func (c *managementTokenCapability) upsert() {
+	c.mu.Lock()
+	cfg := c.cfg
+	c.mu.Unlock()
+
+	hcpManagement := cfg.CloudConfig.ManagementToken
+	if len(hcpManagement) == 0 || cfg.ManagementTokenUpserterFn == nil {
+		return
+	}
+	if err := cfg.ManagementTokenUpserterFn("HCP Management Token", hcpManagement); err != nil {
+		c.logger.Error("failed to upsert HCP management token", "err", err)
+	}
+}
+
+func (c *managementTokenCapability) Stop() error {
+	c.stopOnce.Do(func() {
+		c.mu.Lock()
+		stopCh := c.stopCh
+		c.mu.Unlock()
+		if stopCh != nil {
+			close(stopCh)
+		}
+	})
+	return nil
+}
+
+func (c *managementTokenCapability) OnConfigUpdate(cfg ManagerConfig) {
+	c.mu.Lock()
+	c.cfg = cfg
+	c.mu.Unlock()
+}
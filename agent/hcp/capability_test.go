@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCapability is a minimal Capability used to exercise Manager's
+// start/stop/config-update orchestration without a real subsystem.
+type fakeCapability struct {
+	name     string
+	startErr error
+	stopErr  error
+
+	mu      sync.Mutex
+	started bool
+	stopped bool
+	stops   int
+	updates int
+}
+
+func (c *fakeCapability) Name() string { return c.name }
+
+func (c *fakeCapability) Start(context.Context, ManagerConfig) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.startErr != nil {
+		return c.startErr
+	}
+	c.started = true
+	return nil
+}
+
+func (c *fakeCapability) Stop() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	c.stops++
+	return c.stopErr
+}
+
+func (c *fakeCapability) OnConfigUpdate(ManagerConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.updates++
+}
+
+func (c *fakeCapability) snapshot() (started, stopped bool, updates int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.started, c.stopped, c.updates
+}
+
+func TestManager_StartCapabilities_PartialFailureStillStopsStarted(t *testing.T) {
+	ok := &fakeCapability{name: "ok"}
+	failing := &fakeCapability{name: "failing", startErr: errors.New("boom")}
+	neverReached := &fakeCapability{name: "never-reached"}
+
+	cfg := ManagerConfig{
+		Logger:       hclog.NewNullLogger(),
+		Capabilities: []Capability{ok, failing, neverReached},
+	}
+	m := NewManager(cfg)
+
+	err := m.startCapabilities(context.Background())
+	require.Error(t, err)
+
+	okStarted, _, _ := ok.snapshot()
+	require.True(t, okStarted, "capability before the failing one should have started")
+
+	neverStarted, _, _ := neverReached.snapshot()
+	require.False(t, neverStarted, "capability after the failing one should never be started")
+
+	// Run defers stopCapabilities before calling startCapabilities; check that
+	// stopCapabilities then stops a capability that did start before a later
+	// one failed.
+	m.stopCapabilities()
+	_, okStopped, _ := ok.snapshot()
+	require.True(t, okStopped, "a capability that started must still be stopped after a later one fails")
+}
+
+func TestManager_StopCapabilities_OnlyRunsOnce(t *testing.T) {
+	cap := &fakeCapability{name: "once"}
+	m := NewManager(ManagerConfig{
+		Logger:       hclog.NewNullLogger(),
+		Capabilities: []Capability{cap},
+	})
+
+	require.NoError(t, m.startCapabilities(context.Background()))
+	m.stopCapabilities()
+	m.stopCapabilities()
+
+	// stopCapabilities was called twice above; sync.Once must limit that to a
+	// single Stop call on the capability.
+	_, stopped, _ := cap.snapshot()
+	require.True(t, stopped)
+	cap.mu.Lock()
+	stops := cap.stops
+	cap.mu.Unlock()
+	require.Equal(t, 1, stops, "a second stopCapabilities call must not stop the capability again")
+}
+
+func TestManager_UpdateConfig_NotifiesAllCapabilities(t *testing.T) {
+	a := &fakeCapability{name: "a"}
+	b := &fakeCapability{name: "b"}
+
+	cfg := ManagerConfig{
+		Logger:       hclog.NewNullLogger(),
+		Capabilities: []Capability{a, b},
+	}
+	m := NewManager(cfg)
+
+	m.UpdateConfig(cfg)
+
+	_, _, aUpdates := a.snapshot()
+	_, _, bUpdates := b.snapshot()
+	require.Equal(t, 1, aUpdates)
+	require.Equal(t, 1, bUpdates)
+}
+
+func TestDefaultCapabilities_DisabledCapabilitiesAreFilteredOut(t *testing.T) {
+	all := defaultCapabilities(hclog.NewNullLogger(), nil)
+	require.Len(t, all, 3)
+
+	filtered := defaultCapabilities(hclog.NewNullLogger(), map[string]bool{"telemetry": true})
+	require.Len(t, filtered, 2)
+	for _, c := range filtered {
+		require.NotEqual(t, "telemetry", c.Name())
+	}
+}
+
+func TestManagementTokenCapability_UpsertIntervalDefaultsToMaxInterval(t *testing.T) {
+	cfg := ManagerConfig{MaxInterval: 90 * time.Minute}
+	require.Equal(t, 90*time.Minute, cfg.managementTokenUpsertInterval())
+
+	cfg.ManagementTokenUpsertInterval = time.Minute
+	require.Equal(t, time.Minute, cfg.managementTokenUpsertInterval(),
+		"an explicit interval must override the heartbeat-derived default")
+}
+
+func TestSCADACapability_OnConfigUpdate_StartsProviderSuppliedLater(t *testing.T) {
+	cap := newSCADACapability(hclog.NewNullLogger())
+
+	// SCADA wasn't configured at Start time.
+	require.NoError(t, cap.Start(context.Background(), ManagerConfig{}))
+
+	provider := &fakeSCADAProvider{}
+	cap.OnConfigUpdate(ManagerConfig{SCADAProvider: provider})
+
+	require.True(t, provider.started, "a provider supplied for the first time via OnConfigUpdate must be started")
+}
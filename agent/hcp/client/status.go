@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import "context"
+
+// Client pushes this server's status to HCP and performs other HCP API
+// operations on its behalf.
+type Client interface {
+	PushServerStatus(ctx context.Context, status *ServerStatus) error
+}
+
+// ServerStatus is the payload PushServerStatus sends to HCP on every
+// heartbeat.
+type ServerStatus struct {
+	ID      string
+	Name    string
+	Version string
+
+	// Leaving indicates this status push is the final one sent as part of a
+	// graceful shutdown, so HCP can tell a planned departure apart from a
+	// connection that simply stopped heartbeating.
+	Leaving bool
+
+	// Capabilities reports, by name, which optional Manager subsystems this
+	// server has enabled (see hcp.Capability), so HCP knows which
+	// capability-gated operations this server supports.
+	Capabilities map[string]bool
+}
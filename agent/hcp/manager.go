@@ -13,11 +13,24 @@ import (
 	"github.com/hashicorp/consul/agent/hcp/scada"
 	"github.com/hashicorp/consul/lib"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-metrics"
 )
 
 var (
 	defaultManagerMinInterval = 45 * time.Minute
 	defaultManagerMaxInterval = 75 * time.Minute
+
+	// defaultManagerRetryMaxInterval caps the exponential backoff used when
+	// retrying a failed status push to HCP.
+	defaultManagerRetryMaxInterval = 5 * time.Minute
+
+	// retryInitialInterval is the starting point for the exponential backoff
+	// before jitter is applied.
+	retryInitialInterval = 1 * time.Second
+
+	// defaultDetectHealthyInterval is how often the health watcher checks
+	// whether heartbeats have gone stale.
+	defaultDetectHealthyInterval = 30 * time.Second
 )
 
 type ManagerConfig struct {
@@ -27,12 +40,60 @@ type ManagerConfig struct {
 	TelemetryProvider *hcpProviderImpl
 
 	StatusFn StatusCallback
+	// NodeStatusFn reports this server's current Raft role after each
+	// successful heartbeat. It's not invoked when a push fails or when
+	// SCADAProvider is nil. It's optional; if nil, node status is not
+	// reported to HCP.
+	NodeStatusFn NodeStatusFn
 	// Idempotent function to upsert the HCP management token. This will be called periodically in
 	// the manager's main loop.
 	ManagementTokenUpserterFn ManagementTokenUpserter
 	MinInterval               time.Duration
 	MaxInterval               time.Duration
 
+	// RetryMaxInterval caps the exponential backoff applied between retries of
+	// a failed status push. Defaults to defaultManagerRetryMaxInterval.
+	RetryMaxInterval time.Duration
+
+	// ManagementTokenUpsertInterval controls how often
+	// managementTokenCapability upserts the HCP management token again after
+	// the initial upsert at start. Defaults to MaxInterval. A config update
+	// does not trigger an upsert by itself; a new token is upserted on the
+	// next tick.
+	ManagementTokenUpsertInterval time.Duration
+
+	// Capabilities are the Manager subsystems to start, reconfigure, and stop
+	// alongside the main status-push loop. If left nil, Manager falls back to
+	// the built-in SCADA, telemetry, and management-token capabilities
+	// assembled from the fields above, filtered by DisabledCapabilities.
+	Capabilities []Capability
+
+	// DisabledCapabilities names built-in capabilities (by Capability.Name(),
+	// e.g. "scada", "telemetry", "management-token") to exclude from the
+	// default capability set. It has no effect once Capabilities is set
+	// explicitly.
+	//
+	// TODO: this must be populated in Go today by whoever assembles
+	// ManagerConfig. Exposing per-capability CloudConfig flags (e.g.
+	// disable_scada) that populate this map is follow-up work tracked against
+	// the agent/hcp/config package, out of scope for this series.
+	DisabledCapabilities map[string]bool
+
+	// UnhealthyTimeout is how long the Manager will tolerate heartbeats
+	// failing to succeed before transitioning to an unhealthy state. Defaults
+	// to 3x MaxInterval.
+	UnhealthyTimeout time.Duration
+	// DetectHealthyInterval controls how often the health watcher checks for
+	// a stale heartbeat. Defaults to defaultDetectHealthyInterval.
+	DetectHealthyInterval time.Duration
+	// OnUnhealthy, if set, is invoked when the Manager transitions to
+	// unhealthy, e.g. so the agent can tear down a half-open SCADA session
+	// and force a reconnect.
+	OnUnhealthy func(dur time.Duration)
+	// OnHealthy, if set, is invoked when a heartbeat succeeds after the
+	// Manager had been unhealthy.
+	OnHealthy func()
+
 	Logger hclog.Logger
 }
 
@@ -56,9 +117,62 @@ func (cfg *ManagerConfig) nextHeartbeat() time.Duration {
 	return min + lib.RandomStagger(max-min)
 }
 
+func (cfg *ManagerConfig) retryMaxInterval() time.Duration {
+	if cfg.RetryMaxInterval == 0 {
+		return defaultManagerRetryMaxInterval
+	}
+	return cfg.RetryMaxInterval
+}
+
+func (cfg *ManagerConfig) unhealthyTimeout() time.Duration {
+	if cfg.UnhealthyTimeout != 0 {
+		return cfg.UnhealthyTimeout
+	}
+	max := cfg.MaxInterval
+	if max == 0 {
+		max = defaultManagerMaxInterval
+	}
+	return 3 * max
+}
+
+func (cfg *ManagerConfig) detectHealthyInterval() time.Duration {
+	if cfg.DetectHealthyInterval == 0 {
+		return defaultDetectHealthyInterval
+	}
+	return cfg.DetectHealthyInterval
+}
+
+func (cfg *ManagerConfig) managementTokenUpsertInterval() time.Duration {
+	if cfg.ManagementTokenUpsertInterval != 0 {
+		return cfg.ManagementTokenUpsertInterval
+	}
+	max := cfg.MaxInterval
+	if max == 0 {
+		max = defaultManagerMaxInterval
+	}
+	return max
+}
+
 type StatusCallback func(context.Context) (hcpclient.ServerStatus, error)
 type ManagementTokenUpserter func(name, secretId string) error
 
+// NodeRole describes this server's current position in the Raft cluster, as
+// reported to HCP so link-aware tooling can route management operations to
+// the active leader.
+type NodeRole string
+
+const (
+	NodeRoleUnknown     NodeRole = ""
+	NodeRoleLeader      NodeRole = "leader"
+	NodeRoleFollower    NodeRole = "follower"
+	NodeRoleReadReplica NodeRole = "read-replica"
+)
+
+// NodeStatusFn reports this server's current Raft role. It's invoked after
+// each successful heartbeat so the SCADA provider's metadata can reflect
+// whether this server is a viable target for management operations.
+type NodeStatusFn func(context.Context) (NodeRole, error)
+
 type Manager struct {
 	logger hclog.Logger
 
@@ -67,17 +181,67 @@ type Manager struct {
 
 	updateCh chan struct{}
 
+	// retryMu guards isRetrying and dirty below. It's a dedicated lock rather
+	// than cfgMu so that we never need to hold cfgMu across a retry sleep.
+	retryMu    sync.Mutex
+	isRetrying bool
+	// dirty is set when SendUpdate is called while a retry is already in
+	// flight, so that the in-flight attempt re-reads StatusFn on its next try
+	// instead of a second retry loop being started.
+	dirty bool
+
 	// testUpdateSent is set by unit tests to signal when the manager's status update has triggered
 	testUpdateSent chan struct{}
+	// testRetrySent is set by unit tests to signal when a retry attempt has completed
+	testRetrySent chan struct{}
+
+	// heartbeatMu guards lastHeartbeat and nodeRole below.
+	heartbeatMu   sync.RWMutex
+	lastHeartbeat time.Time
+	nodeRole      NodeRole
+
+	// healthMu guards unhealthy below.
+	healthMu  sync.Mutex
+	unhealthy bool
+
+	// capabilities are the registered Manager subsystems, started and stopped
+	// alongside the main loop. See Capability.
+	capabilities []Capability
+	// stopCapsOnce ensures capabilities are stopped exactly once, whether that
+	// happens via Leave or via Run's own shutdown.
+	stopCapsOnce sync.Once
+
+	// capStatusMu guards capStatus below.
+	capStatusMu sync.RWMutex
+	capStatus   map[string]bool
+
+	// leaving is closed by Leave to signal that this server is departing, so
+	// that sendUpdate can short-circuit rather than race a heartbeat against
+	// the departure notice.
+	leaving   chan struct{}
+	leaveOnce sync.Once
+	// leaveErr is the outcome of the departure notification, set once inside
+	// leaveOnce.Do. Every concurrent Leave caller returns this rather than a
+	// per-call local, since only the caller that wins the race would
+	// otherwise observe the real result.
+	leaveErr error
 }
 
 // NewManager returns a Manager initialized with the given configuration.
 func NewManager(cfg ManagerConfig) *Manager {
+	capabilities := cfg.Capabilities
+	if len(capabilities) == 0 {
+		capabilities = defaultCapabilities(cfg.Logger, cfg.DisabledCapabilities)
+	}
+
 	return &Manager{
 		logger: cfg.Logger,
 		cfg:    cfg,
 
 		updateCh: make(chan struct{}, 1),
+
+		capabilities: capabilities,
+		leaving:      make(chan struct{}),
 	}
 }
 
@@ -90,39 +254,27 @@ func (m *Manager) Run(ctx context.Context) error {
 	var err error
 	m.logger.Debug("HCP manager starting")
 
-	// Update and start the SCADA provider
-	err = m.startSCADAProvider()
-	if err != nil {
-		m.logger.Error("failed to start scada provider", "error", err)
+	// Stop whatever capabilities did start even if one of them failed,
+	// otherwise a later capability failing to start leaks the ones before it.
+	defer m.stopCapabilities()
+	if err := m.startCapabilities(ctx); err != nil {
 		return err
 	}
 
-	// Update and start the telemetry provider to enable the HCP metrics sink
-	if err := m.startTelemetryProvider(ctx); err != nil {
-		m.logger.Error("failed to update telemetry config provider", "error", err)
-		return err
-	}
+	go m.watchHealth(ctx)
 
 	// immediately send initial update
 	select {
 	case <-ctx.Done():
 		return nil
 	case <-m.updateCh: // empty the update chan if there is a queued update to prevent repeated update in main loop
-		err = m.sendUpdate()
+		err = m.sendUpdate(ctx)
 	default:
-		err = m.sendUpdate()
+		err = m.sendUpdate(ctx)
 	}
 
 	// main loop
 	for {
-		// Check for configured management token from HCP and upsert it if found
-		if hcpManagement := m.cfg.CloudConfig.ManagementToken; len(hcpManagement) > 0 {
-			upsertTokenErr := m.cfg.ManagementTokenUpserterFn("HCP Management Token", hcpManagement)
-			if upsertTokenErr != nil {
-				m.logger.Error("failed to upsert HCP management token", "err", upsertTokenErr)
-			}
-		}
-
 		m.cfgMu.RLock()
 		cfg := m.cfg
 		m.cfgMu.RUnlock()
@@ -136,59 +288,77 @@ func (m *Manager) Run(ctx context.Context) error {
 			return nil
 
 		case <-m.updateCh:
-			err = m.sendUpdate()
+			err = m.sendUpdate(ctx)
 
 		case <-time.After(nextUpdate):
-			err = m.sendUpdate()
+			err = m.sendUpdate(ctx)
 		}
 	}
 }
 
-func (m *Manager) startSCADAProvider() error {
-	provider := m.cfg.SCADAProvider
-	if provider == nil {
-		return nil
-	}
+// startCapabilities starts every registered capability against the current
+// config, recording each one's outcome in capStatus. The first failure stops
+// the manager from starting, matching the previous hard-coded SCADA/telemetry
+// bring-up behavior.
+func (m *Manager) startCapabilities(ctx context.Context) error {
+	m.cfgMu.RLock()
+	cfg := m.cfg
+	m.cfgMu.RUnlock()
 
-	// Update the SCADA provider configuration with HCP configurations
-	m.logger.Debug("updating scada provider with HCP configuration")
-	err := provider.UpdateHCPConfig(m.cfg.CloudConfig)
-	if err != nil {
-		m.logger.Error("failed to update scada provider with HCP configuration", "err", err)
-		return err
+	status := make(map[string]bool, len(m.capabilities))
+	for _, c := range m.capabilities {
+		err := c.Start(ctx, cfg)
+		status[c.Name()] = err == nil
+		if err != nil {
+			m.logger.Error("failed to start HCP manager capability", "capability", c.Name(), "error", err)
+			m.capStatusMu.Lock()
+			m.capStatus = status
+			m.capStatusMu.Unlock()
+			return err
+		}
 	}
 
-	// Update the SCADA provider metadata
-	provider.UpdateMeta(map[string]string{
-		"consul_server_id": string(m.cfg.CloudConfig.NodeID),
-	})
-
-	// Start the SCADA provider
-	err = provider.Start()
-	if err != nil {
-		return err
-	}
+	m.capStatusMu.Lock()
+	m.capStatus = status
+	m.capStatusMu.Unlock()
 	return nil
 }
 
-func (m *Manager) startTelemetryProvider(ctx context.Context) error {
-	if m.cfg.TelemetryProvider == nil {
-		return nil
-	}
-
-	m.cfg.TelemetryProvider.Run(ctx, &HCPProviderCfg{
-		HCPClient: m.cfg.Client,
-		HCPConfig: &m.cfg.CloudConfig,
+// stopCapabilities stops every registered capability, logging but not
+// failing on individual errors since Run is already tearing down. It's safe
+// to call more than once: Leave and Run's own shutdown path both call it, and
+// only the first call does anything.
+func (m *Manager) stopCapabilities() {
+	m.stopCapsOnce.Do(func() {
+		for _, c := range m.capabilities {
+			if err := c.Stop(); err != nil {
+				m.logger.Warn("failed to stop HCP manager capability", "capability", c.Name(), "error", err)
+			}
+		}
 	})
+}
 
-	return nil
+// CapabilityStatus reports whether each registered capability started
+// successfully. It's attached to every status push (see pushUpdate and
+// Leave) as ServerStatus.Capabilities, so HCP knows which capability-gated
+// operations a given server supports.
+func (m *Manager) CapabilityStatus() map[string]bool {
+	m.capStatusMu.RLock()
+	defer m.capStatusMu.RUnlock()
+
+	status := make(map[string]bool, len(m.capStatus))
+	for k, v := range m.capStatus {
+		status[k] = v
+	}
+	return status
 }
 
 func (m *Manager) UpdateConfig(cfg ManagerConfig) {
 	m.cfgMu.Lock()
-	defer m.cfgMu.Unlock()
 	old := m.cfg
 	m.cfg = cfg
+	m.cfgMu.Unlock()
+
 	if old.enabled() || cfg.enabled() {
 		// Only log about this if cloud is actually configured or it would be
 		// confusing. We check both old and new in case we are disabling cloud or
@@ -196,11 +366,68 @@ func (m *Manager) UpdateConfig(cfg ManagerConfig) {
 		m.logger.Info("updated HCP configuration")
 	}
 
+	for _, c := range m.capabilities {
+		c.OnConfigUpdate(cfg)
+	}
+
 	// Send a new status update since we might have just gotten connection details
 	// for the first time.
 	m.SendUpdate()
 }
 
+// isLeaving reports whether Leave has been called.
+func (m *Manager) isLeaving() bool {
+	select {
+	case <-m.leaving:
+		return true
+	default:
+		return false
+	}
+}
+
+// Leave notifies HCP that this server is departing, so that it shows up as a
+// planned departure rather than a connection that simply stopped
+// heartbeating. It's wired from the agent's shutdown sequence and must be
+// called before the context passed to Run is cancelled. Multiple callers
+// coalesce into a single notification via leaveOnce.
+func (m *Manager) Leave(ctx context.Context) error {
+	m.leaveOnce.Do(func() {
+		close(m.leaving)
+		m.logger.Info("notifying HCP that this server is leaving")
+
+		m.cfgMu.RLock()
+		cfg := m.cfg
+		m.cfgMu.RUnlock()
+
+		if cfg.enabled() {
+			pushCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			s, statusErr := cfg.StatusFn(pushCtx)
+			if statusErr != nil {
+				m.logger.Warn("failed to read status for HCP leave notification", "error", statusErr)
+			} else {
+				s.Leaving = true
+				s.Capabilities = m.CapabilityStatus()
+				if pushErr := cfg.Client.PushServerStatus(pushCtx, &s); pushErr != nil {
+					m.logger.Warn("failed to send leave notification to HCP", "error", pushErr)
+					m.leaveErr = pushErr
+				}
+			}
+		}
+
+		if cfg.SCADAProvider != nil {
+			cfg.SCADAProvider.UpdateMeta(map[string]string{
+				"consul_server_id":   string(cfg.CloudConfig.NodeID),
+				"consul.node_status": "leaving",
+			})
+		}
+
+		m.stopCapabilities()
+	})
+	return m.leaveErr
+}
+
 func (m *Manager) SendUpdate() {
 	m.logger.Debug("HCP triggering status update")
 	select {
@@ -212,22 +439,12 @@ func (m *Manager) SendUpdate() {
 	}
 }
 
-// TODO: we should have retried on failures here with backoff but take into
-// account that if a new update is triggered while we are still retrying we
-// should not start another retry loop. Something like have a "dirty" flag which
-// we mark on first PushUpdate and then a retry timer as well as the interval
-// and a "isRetrying" state or something so that we attempt to send update, but
-// then fetch fresh info on each attempt to send so if we are already in a retry
-// backoff a new push is a no-op.
-func (m *Manager) sendUpdate() error {
-	m.cfgMu.RLock()
-	cfg := m.cfg
-	m.cfgMu.RUnlock()
-
-	if !cfg.enabled() {
-		return nil
-	}
-
+// sendUpdate pushes a status update to HCP. If the push fails, it kicks off a
+// background retry loop so that the failure is not silently swallowed until
+// the next heartbeat, which may be up to 75 minutes away. If a retry loop is
+// already running, it only marks the manager as dirty and leaves the push to
+// that loop.
+func (m *Manager) sendUpdate(ctx context.Context) error {
 	if m.testUpdateSent != nil {
 		defer func() {
 			select {
@@ -237,6 +454,55 @@ func (m *Manager) sendUpdate() error {
 		}()
 	}
 
+	m.retryMu.Lock()
+	if m.isRetrying {
+		// A retry loop already owns pushing updates to HCP; don't race it
+		// with a concurrent push from here. Just mark dirty so it re-reads
+		// StatusFn on its next attempt.
+		m.dirty = true
+		m.retryMu.Unlock()
+		return nil
+	}
+	m.retryMu.Unlock()
+
+	err := m.pushUpdate()
+	if err == nil {
+		return nil
+	}
+
+	m.retryMu.Lock()
+	if m.isRetrying {
+		// A retry loop started concurrently while we were pushing; mark dirty
+		// so it re-reads StatusFn on its next attempt instead of us starting
+		// a second loop.
+		m.dirty = true
+		m.retryMu.Unlock()
+		return err
+	}
+	m.isRetrying = true
+	m.retryMu.Unlock()
+
+	go m.retryUpdate(ctx)
+
+	return err
+}
+
+// pushUpdate performs a single attempt to read fresh status and push it to HCP.
+func (m *Manager) pushUpdate() error {
+	if m.isLeaving() {
+		// Leave already sent the final departure notice; don't race it with
+		// a regular heartbeat.
+		return nil
+	}
+
+	m.cfgMu.RLock()
+	cfg := m.cfg
+	m.cfgMu.RUnlock()
+
+	if !cfg.enabled() {
+		return nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -244,6 +510,221 @@ func (m *Manager) sendUpdate() error {
 	if err != nil {
 		return err
 	}
+	s.Capabilities = m.CapabilityStatus()
+
+	if err := cfg.Client.PushServerStatus(ctx, &s); err != nil {
+		return err
+	}
+
+	m.heartbeatMu.Lock()
+	m.lastHeartbeat = time.Now()
+	m.heartbeatMu.Unlock()
+
+	m.recordHealthy(cfg)
+	m.checkNodeStatus(ctx, cfg)
+
+	return nil
+}
+
+// recordHealthy clears the unhealthy state set by watchHealth, if any, and
+// fires cfg.OnHealthy on the transition back to healthy.
+func (m *Manager) recordHealthy(cfg ManagerConfig) {
+	m.healthMu.Lock()
+	wasUnhealthy := m.unhealthy
+	m.unhealthy = false
+	m.healthMu.Unlock()
+
+	if !wasUnhealthy {
+		return
+	}
+
+	m.logger.Info("HCP heartbeat has recovered")
+	if cfg.OnHealthy != nil {
+		cfg.OnHealthy()
+	}
+}
+
+// watchHealth periodically checks whether heartbeats have gone stale and, if
+// so, transitions the Manager to an unhealthy state.
+func (m *Manager) watchHealth(ctx context.Context) {
+	m.cfgMu.RLock()
+	interval := m.cfg.detectHealthyInterval()
+	m.cfgMu.RUnlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkHealth()
+		}
+	}
+}
+
+// checkHealth transitions the Manager to unhealthy if too long has passed
+// since the last successful heartbeat.
+func (m *Manager) checkHealth() {
+	last := m.LastHeartbeat()
+	if last.IsZero() {
+		return
+	}
+
+	m.cfgMu.RLock()
+	cfg := m.cfg
+	m.cfgMu.RUnlock()
+
+	since := time.Since(last)
+	timeout := cfg.unhealthyTimeout()
+	if since <= timeout {
+		return
+	}
+
+	m.healthMu.Lock()
+	alreadyUnhealthy := m.unhealthy
+	m.unhealthy = true
+	m.healthMu.Unlock()
+
+	if alreadyUnhealthy {
+		return
+	}
+
+	m.logger.Warn("no successful HCP heartbeat recently, marking unhealthy",
+		"since_last_heartbeat", since.String(), "timeout", timeout.String())
+	metrics.IncrCounter([]string{"hcp", "unhealthy"}, 1)
+
+	if cfg.OnUnhealthy != nil {
+		cfg.OnUnhealthy(since)
+	}
+}
+
+// Healthy reports false from the time the health watcher finds no successful
+// heartbeat within UnhealthyTimeout until the next successful heartbeat, and
+// true otherwise, including before the first successful heartbeat.
+func (m *Manager) Healthy() bool {
+	m.healthMu.Lock()
+	defer m.healthMu.Unlock()
+	return !m.unhealthy
+}
+
+// checkNodeStatus invokes cfg.NodeStatusFn and pushes this server's Raft role
+// and last heartbeat time to the SCADA provider's metadata on every
+// successful heartbeat, so consul.node_status.last_heartbeat stays fresh even
+// once this server settles into a stable role for the rest of the heartbeat
+// interval (or longer, if the role never changes again). A role change is
+// picked up only at the next successful heartbeat; changes between
+// heartbeats are not observed.
+func (m *Manager) checkNodeStatus(ctx context.Context, cfg ManagerConfig) {
+	if cfg.NodeStatusFn == nil || cfg.SCADAProvider == nil {
+		return
+	}
+
+	role, err := cfg.NodeStatusFn(ctx)
+	if err != nil {
+		m.logger.Warn("failed to determine node status for HCP", "error", err)
+		return
+	}
+
+	m.heartbeatMu.Lock()
+	m.nodeRole = role
+	lastHeartbeat := m.lastHeartbeat
+	m.heartbeatMu.Unlock()
+
+	cfg.SCADAProvider.UpdateMeta(map[string]string{
+		"consul_server_id":                  string(cfg.CloudConfig.NodeID),
+		"consul.node_status":                string(role),
+		"consul.node_status.last_heartbeat": lastHeartbeat.Format(time.RFC3339),
+	})
+}
+
+// LastHeartbeat returns the time of the last successful status push to HCP,
+// or the zero Time if none has succeeded yet.
+func (m *Manager) LastHeartbeat() time.Time {
+	m.heartbeatMu.RLock()
+	defer m.heartbeatMu.RUnlock()
+	return m.lastHeartbeat
+}
+
+// Status summarizes the Manager's HCP connectivity state, intended to back an
+// agent HTTP endpoint (e.g. /v1/agent/hcp/status) so operators and the link
+// capability can observe state without scraping logs.
+type Status struct {
+	LastHeartbeat time.Time
+	NodeRole      NodeRole
+	Healthy       bool
+}
+
+// Status returns a snapshot of the Manager's current HCP connectivity state.
+func (m *Manager) Status() Status {
+	m.heartbeatMu.RLock()
+	lastHeartbeat := m.lastHeartbeat
+	nodeRole := m.nodeRole
+	m.heartbeatMu.RUnlock()
+
+	return Status{
+		LastHeartbeat: lastHeartbeat,
+		NodeRole:      nodeRole,
+		Healthy:       m.Healthy(),
+	}
+}
+
+// retryUpdate retries pushUpdate with exponential backoff and jitter until it
+// succeeds or ctx is cancelled. It must be started in its own goroutine and
+// must not hold cfgMu while sleeping between attempts.
+func (m *Manager) retryUpdate(ctx context.Context) {
+	defer func() {
+		m.retryMu.Lock()
+		m.isRetrying = false
+		m.dirty = false
+		m.retryMu.Unlock()
+	}()
+
+	m.cfgMu.RLock()
+	maxInterval := m.cfg.retryMaxInterval()
+	m.cfgMu.RUnlock()
+
+	interval := retryInitialInterval
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(lib.RandomStagger(interval)):
+		}
+
+		m.retryMu.Lock()
+		m.dirty = false
+		m.retryMu.Unlock()
 
-	return m.cfg.Client.PushServerStatus(ctx, &s)
+		err := m.pushUpdate()
+
+		if m.testRetrySent != nil {
+			select {
+			case m.testRetrySent <- struct{}{}:
+			default:
+			}
+		}
+
+		if err == nil {
+			m.retryMu.Lock()
+			dirty := m.dirty
+			m.retryMu.Unlock()
+			if !dirty {
+				return
+			}
+			// A new update was requested while we were pushing this one;
+			// loop again after the initial backoff interval to pick it up.
+			interval = retryInitialInterval
+			continue
+		}
+
+		m.logger.Warn("failed to send server status to HCP, retrying", "error", err, "retry_interval", interval.String())
+		metrics.IncrCounter([]string{"hcp", "manager", "retry"}, 1)
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
 }
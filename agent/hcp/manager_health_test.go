@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hcp
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_HealthWatcher_TransitionsUnhealthyThenRecovers(t *testing.T) {
+	client := &fakeClient{}
+	cfg := testManagerConfig(t, client)
+	cfg.MinInterval = time.Hour
+	cfg.MaxInterval = time.Hour
+	cfg.UnhealthyTimeout = 20 * time.Millisecond
+	cfg.DetectHealthyInterval = 5 * time.Millisecond
+
+	var mu sync.Mutex
+	var unhealthyCalls, healthyCalls int
+	cfg.OnUnhealthy = func(time.Duration) {
+		mu.Lock()
+		unhealthyCalls++
+		mu.Unlock()
+	}
+	cfg.OnHealthy = func() {
+		mu.Lock()
+		healthyCalls++
+		mu.Unlock()
+	}
+
+	m := NewManager(cfg)
+
+	// Seed a successful heartbeat in the past so checkHealth has a baseline
+	// to compare against, without needing a real push.
+	m.heartbeatMu.Lock()
+	m.lastHeartbeat = time.Now().Add(-time.Hour)
+	m.heartbeatMu.Unlock()
+
+	m.checkHealth()
+	require.False(t, m.Healthy())
+
+	mu.Lock()
+	require.Equal(t, 1, unhealthyCalls)
+	mu.Unlock()
+
+	// A second check while still unhealthy must not re-fire OnUnhealthy.
+	m.checkHealth()
+	mu.Lock()
+	require.Equal(t, 1, unhealthyCalls)
+	mu.Unlock()
+
+	// A subsequent successful push should recover and fire OnHealthy.
+	require.NoError(t, m.pushUpdate())
+	require.True(t, m.Healthy())
+
+	mu.Lock()
+	require.Equal(t, 1, healthyCalls)
+	mu.Unlock()
+}
+
+func TestManager_HealthWatcher_NoBaselineIsNoOp(t *testing.T) {
+	client := &fakeClient{failN: 1, err: errors.New("boom")}
+	cfg := testManagerConfig(t, client)
+
+	m := NewManager(cfg)
+
+	// No heartbeat has ever succeeded, so checkHealth should not flip to
+	// unhealthy just because the zero value looks "old".
+	m.checkHealth()
+	require.True(t, m.Healthy())
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Leave_SendsDepartureAndShortCircuitsHeartbeats(t *testing.T) {
+	client := &fakeClient{}
+	provider := &fakeSCADAProvider{}
+	cfg := testManagerConfig(t, client)
+	cfg.SCADAProvider = provider
+	cfg.Capabilities = []Capability{newSCADACapability(cfg.Logger)}
+
+	m := NewManager(cfg)
+	require.NoError(t, m.startCapabilities(context.Background()))
+
+	require.NoError(t, m.Leave(context.Background()))
+
+	require.Len(t, client.pushes, 1)
+	require.True(t, client.pushes[0].Leaving, "the departure push must set Leaving")
+
+	require.Equal(t, "leaving", provider.meta["consul.node_status"])
+	require.True(t, provider.stopped, "Leave must stop the SCADA capability")
+
+	// Once leaving, regular heartbeats must be short-circuited rather than
+	// racing the departure notice.
+	require.NoError(t, m.pushUpdate())
+	require.Len(t, client.pushes, 1, "pushUpdate must no-op after Leave")
+}
+
+func TestManager_Leave_CoalescesMultipleCallers(t *testing.T) {
+	client := &fakeClient{}
+	cfg := testManagerConfig(t, client)
+
+	m := NewManager(cfg)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, m.Leave(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, client.pushes, 1, "concurrent Leave callers must coalesce into a single notification")
+}
+
+func TestManager_Leave_PropagatesErrorToEveryConcurrentCaller(t *testing.T) {
+	wantErr := errors.New("boom")
+	client := &fakeClient{failN: 1, err: wantErr}
+	cfg := testManagerConfig(t, client)
+
+	m := NewManager(cfg)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = m.Leave(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	// Only the caller that actually runs leaveOnce.Do observes the push
+	// result directly; every other concurrent caller must still see it via
+	// m.leaveErr rather than silently returning nil.
+	for i, err := range errs {
+		require.ErrorIs(t, err, wantErr, "caller %d should see the departure push failure", i)
+	}
+}
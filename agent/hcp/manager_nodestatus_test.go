@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hcp
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/consul/agent/hcp/config"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSCADAProvider is a minimal scada.Provider used to observe metadata
+// updates without a real SCADA session.
+type fakeSCADAProvider struct {
+	mu        sync.Mutex
+	meta      map[string]string
+	metaCalls int
+	started   bool
+	stopped   bool
+}
+
+func (f *fakeSCADAProvider) UpdateHCPConfig(config.CloudConfig) error { return nil }
+
+func (f *fakeSCADAProvider) UpdateMeta(meta map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.meta = meta
+	f.metaCalls++
+}
+
+func (f *fakeSCADAProvider) Start() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.started = true
+	return nil
+}
+
+func (f *fakeSCADAProvider) Stop() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopped = true
+	return nil
+}
+
+func (f *fakeSCADAProvider) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.metaCalls
+}
+
+func TestManager_CheckNodeStatus_RefreshesOnEverySuccessfulHeartbeat(t *testing.T) {
+	provider := &fakeSCADAProvider{}
+	client := &fakeClient{}
+	cfg := testManagerConfig(t, client)
+	cfg.SCADAProvider = provider
+	cfg.NodeStatusFn = func(context.Context) (NodeRole, error) {
+		return NodeRoleLeader, nil
+	}
+
+	m := NewManager(cfg)
+
+	require.NoError(t, m.pushUpdate())
+	require.NoError(t, m.pushUpdate())
+	require.NoError(t, m.pushUpdate())
+
+	// Metadata must be refreshed on every successful heartbeat, not only the
+	// first time the role is observed, so last_heartbeat doesn't go stale
+	// while this server stays in a stable role.
+	require.Equal(t, 3, provider.callCount())
+	require.Equal(t, string(NodeRoleLeader), provider.meta["consul.node_status"])
+}
+
+func TestManager_CheckNodeStatus_NilWhenNoSCADAOrStatusFn(t *testing.T) {
+	client := &fakeClient{}
+	cfg := testManagerConfig(t, client)
+	// No SCADAProvider, no NodeStatusFn: checkNodeStatus must be a no-op.
+	m := NewManager(cfg)
+
+	require.NotPanics(t, func() {
+		m.checkNodeStatus(context.Background(), cfg)
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	hcpclient "github.com/hashicorp/consul/agent/hcp/client"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient is a minimal hcpclient.Client used to observe and control what
+// Manager pushes to HCP without talking to a real server.
+type fakeClient struct {
+	mu sync.Mutex
+
+	// failN causes the next failN calls to PushServerStatus to return err,
+	// after which subsequent calls succeed.
+	failN int
+	err   error
+
+	pushes []hcpclient.ServerStatus
+}
+
+func (f *fakeClient) PushServerStatus(_ context.Context, s *hcpclient.ServerStatus) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failN > 0 {
+		f.failN--
+		return f.err
+	}
+
+	f.pushes = append(f.pushes, *s)
+	return nil
+}
+
+func (f *fakeClient) pushCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.pushes)
+}
+
+func testManagerConfig(t *testing.T, client hcpclient.Client) ManagerConfig {
+	t.Helper()
+	return ManagerConfig{
+		Client: client,
+		StatusFn: func(context.Context) (hcpclient.ServerStatus, error) {
+			return hcpclient.ServerStatus{ID: "test-server"}, nil
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+}
+
+func TestManager_SendUpdate_RetriesWithBackoffUntilSuccess(t *testing.T) {
+	// Speed up the backoff schedule for the test.
+	oldInitial := retryInitialInterval
+	retryInitialInterval = time.Millisecond
+	t.Cleanup(func() { retryInitialInterval = oldInitial })
+
+	client := &fakeClient{failN: 2, err: errors.New("transient")}
+	cfg := testManagerConfig(t, client)
+	cfg.RetryMaxInterval = 10 * time.Millisecond
+
+	m := NewManager(cfg)
+	m.testRetrySent = make(chan struct{}, 8)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := m.sendUpdate(ctx)
+	require.Error(t, err, "first attempt should surface the transient failure")
+
+	// Drain retry completions until the push finally succeeds.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-m.testRetrySent:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for retry attempt")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		return client.pushCount() == 1
+	}, time.Second, time.Millisecond, "retry loop should eventually push successfully")
+
+	require.Eventually(t, func() bool {
+		m.retryMu.Lock()
+		defer m.retryMu.Unlock()
+		return !m.isRetrying
+	}, time.Second, time.Millisecond, "retry loop should exit once it succeeds")
+}
+
+func TestManager_SendUpdate_DirtyCoalescesConcurrentTrigger(t *testing.T) {
+	oldInitial := retryInitialInterval
+	retryInitialInterval = 50 * time.Millisecond
+	t.Cleanup(func() { retryInitialInterval = oldInitial })
+
+	client := &fakeClient{failN: 1, err: errors.New("transient")}
+	cfg := testManagerConfig(t, client)
+	cfg.RetryMaxInterval = 200 * time.Millisecond
+
+	m := NewManager(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := m.sendUpdate(ctx)
+	require.Error(t, err)
+
+	m.retryMu.Lock()
+	require.True(t, m.isRetrying, "a retry loop should now be in flight")
+	m.retryMu.Unlock()
+
+	// A second trigger while the retry is in flight must not start a second
+	// retry loop; it should just mark dirty.
+	secondErr := m.sendUpdate(ctx)
+	require.NoError(t, secondErr, "a concurrent trigger should be coalesced, not pushed again here")
+
+	m.retryMu.Lock()
+	dirty := m.dirty
+	m.retryMu.Unlock()
+	require.True(t, dirty, "concurrent trigger during retry should set dirty")
+
+	require.Eventually(t, func() bool {
+		m.retryMu.Lock()
+		defer m.retryMu.Unlock()
+		return !m.isRetrying
+	}, time.Second, time.Millisecond)
+
+	// The coalesced trigger is served by the single retry loop rather than a
+	// second concurrent pusher, so at least that loop's push must have
+	// landed.
+	require.GreaterOrEqual(t, client.pushCount(), 1)
+}
+
+func TestManager_Run_StopsOnContextDone(t *testing.T) {
+	client := &fakeClient{}
+	cfg := testManagerConfig(t, client)
+	cfg.MinInterval = time.Hour
+	cfg.MaxInterval = time.Hour
+
+	m := NewManager(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx) }()
+
+	require.Eventually(t, func() bool { return client.pushCount() >= 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after ctx was cancelled")
+	}
+}